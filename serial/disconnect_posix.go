@@ -0,0 +1,20 @@
+//go:build !windows
+
+package serial
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+// isDisconnectErr reports whether err looks like the device was physically
+// removed (as opposed to some other read/write failure), which is what
+// should trigger a Supervisor reconnect. On POSIX, a yanked USB-serial
+// adapter typically surfaces as EIO or ENXIO, sometimes preceded by an EOF.
+func isDisconnectErr(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.EIO) ||
+		errors.Is(err, syscall.ENXIO) ||
+		errors.Is(err, syscall.ENODEV)
+}
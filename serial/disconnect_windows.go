@@ -0,0 +1,22 @@
+//go:build windows
+
+package serial
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+// isDisconnectErr reports whether err looks like the device was physically
+// removed, which is what should trigger a Supervisor reconnect. On Windows,
+// a yanked USB-serial adapter surfaces as ERROR_DEVICE_REMOVED (or, once the
+// handle is already torn down, ERROR_INVALID_HANDLE) from ReadFile/WriteFile.
+func isDisconnectErr(err error) bool {
+	const errorDeviceRemoved = syscall.Errno(1617)
+	const errorInvalidHandle = syscall.Errno(6)
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, errorDeviceRemoved) ||
+		errors.Is(err, errorInvalidHandle)
+}
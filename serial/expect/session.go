@@ -0,0 +1,169 @@
+// Package expect drives line-oriented, prompt-based sessions (Cisco-style
+// IOS, U-Boot, Arduino REPLs, and similar) over a port opened with
+// serial.Open, so callers don't each reinvent CR/LF handling and
+// read-with-timeout.
+package expect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// CRLFMode controls how a Session normalizes line endings.
+type CRLFMode int
+
+const (
+	// CRLFNone leaves outgoing lines and incoming data untouched.
+	CRLFNone CRLFMode = iota
+
+	// CRLFNormalize appends "\r\n" to each line passed to Send, and strips
+	// '\r' bytes from incoming data before it is matched or returned. This
+	// is the default, since it's what most serial CLIs expect.
+	CRLFNormalize
+)
+
+// echoTimeout bounds how long SuppressEcho waits for a sent line to be
+// echoed back before giving up and continuing anyway.
+const echoTimeout = 500 * time.Millisecond
+
+// Session drives a prompt-based device over an already-opened
+// io.ReadWriteCloser, typically the one returned by serial.Open. It reads in
+// a background goroutine so that Expect can apply a per-call timeout
+// without losing bytes received in between calls.
+type Session struct {
+	rwc io.ReadWriteCloser
+
+	// CRLF selects how line endings are handled. Defaults to
+	// CRLFNormalize.
+	CRLF CRLFMode
+
+	// SuppressEcho discards a leading echo of each sent line before the
+	// caller's next Expect is allowed to match, for devices (most serial
+	// CLIs) that echo back what was typed.
+	SuppressEcho bool
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	readErr error
+	notify  chan struct{}
+}
+
+// NewSession starts a background reader over rwc and returns a Session
+// ready to drive it. The caller remains responsible for closing rwc (or may
+// use Session.Close as a shorthand).
+func NewSession(rwc io.ReadWriteCloser) *Session {
+	s := &Session{
+		rwc:    rwc,
+		CRLF:   CRLFNormalize,
+		notify: make(chan struct{}, 1),
+	}
+	go s.readLoop()
+	return s
+}
+
+// Close closes the underlying port.
+func (s *Session) Close() error {
+	return s.rwc.Close()
+}
+
+func (s *Session) readLoop() {
+	tmp := make([]byte, 4096)
+	for {
+		n, err := s.rwc.Read(tmp)
+		if n > 0 {
+			chunk := tmp[:n]
+			if s.CRLF == CRLFNormalize {
+				chunk = bytes.ReplaceAll(chunk, []byte{'\r'}, nil)
+			}
+			s.mu.Lock()
+			s.buf.Write(chunk)
+			s.mu.Unlock()
+		}
+		if err != nil {
+			s.mu.Lock()
+			s.readErr = err
+			s.mu.Unlock()
+			s.wake()
+			return
+		}
+		if n > 0 {
+			s.wake()
+		}
+	}
+}
+
+// wake notifies a blocked Expect call that the buffer or readErr changed,
+// without blocking if nobody is currently waiting.
+func (s *Session) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Send writes line to the port. If CRLF is CRLFNormalize (the default), a
+// trailing "\r\n" is appended. If SuppressEcho is set, Send then discards a
+// leading echo of line from the incoming stream before returning.
+func (s *Session) Send(line string) error {
+	payload := []byte(line)
+	out := payload
+	if s.CRLF == CRLFNormalize {
+		out = append(append([]byte(nil), payload...), '\r', '\n')
+	}
+
+	if _, err := s.rwc.Write(out); err != nil {
+		return fmt.Errorf("expect: send %q: %w", line, err)
+	}
+
+	if s.SuppressEcho && len(payload) > 0 {
+		// Best effort: most devices echo promptly, but a device that
+		// doesn't echo at all shouldn't make Send fail.
+		s.Expect(regexp.MustCompile(regexp.QuoteMeta(string(payload))), echoTimeout)
+	}
+
+	return nil
+}
+
+// Expect blocks until data matching pattern has been read from the port, or
+// timeout elapses. On success it returns the matched bytes and consumes
+// everything up to and including the match, so the next Expect only sees
+// what comes after.
+func (s *Session) Expect(pattern *regexp.Regexp, timeout time.Duration) ([]byte, error) {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		data := s.buf.Bytes()
+		loc := pattern.FindIndex(data)
+		if loc != nil {
+			match := append([]byte(nil), data[loc[0]:loc[1]]...)
+			s.buf.Next(loc[1])
+			s.mu.Unlock()
+			return match, nil
+		}
+		readErr := s.readErr
+		s.mu.Unlock()
+
+		if readErr != nil {
+			return nil, fmt.Errorf("expect: waiting for %q: %w", pattern, readErr)
+		}
+
+		select {
+		case <-s.notify:
+		case <-deadline:
+			return nil, fmt.Errorf("expect: timed out after %v waiting for %q", timeout, pattern)
+		}
+	}
+}
+
+// SendAndExpect sends line and then waits for pattern, as a convenience for
+// the common request/response step.
+func (s *Session) SendAndExpect(line string, pattern *regexp.Regexp, timeout time.Duration) ([]byte, error) {
+	if err := s.Send(line); err != nil {
+		return nil, err
+	}
+	return s.Expect(pattern, timeout)
+}
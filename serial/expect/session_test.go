@@ -0,0 +1,105 @@
+package expect
+
+import (
+	"net"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// fakeDevice hands back a Session wired to one end of an in-memory pipe; the
+// test drives the other end to play the part of the device.
+func fakeDevice(t *testing.T) (*Session, net.Conn) {
+	t.Helper()
+	client, device := net.Pipe()
+	t.Cleanup(func() { client.Close(); device.Close() })
+	return NewSession(client), device
+}
+
+func TestExpectMatchesAndConsumes(t *testing.T) {
+	s, device := fakeDevice(t)
+
+	go device.Write([]byte("booting...\r\nlogin: "))
+
+	match, err := s.Expect(regexp.MustCompile(`login: $`), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(match) != "login: " {
+		t.Errorf("match = %q, want %q", match, "login: ")
+	}
+}
+
+func TestExpectTimesOut(t *testing.T) {
+	s, _ := fakeDevice(t)
+
+	_, err := s.Expect(regexp.MustCompile(`never`), 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
+
+func TestSendAndExpect(t *testing.T) {
+	s, device := fakeDevice(t)
+
+	go func() {
+		buf := make([]byte, 64)
+		n, _ := device.Read(buf)
+		if string(buf[:n]) == "show version\r\n" {
+			device.Write([]byte("Cisco IOS Software\r\nRouter> "))
+		}
+	}()
+
+	match, err := s.SendAndExpect("show version", regexp.MustCompile(`Router> $`), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(match) != "Router> " {
+		t.Errorf("match = %q, want %q", match, "Router> ")
+	}
+}
+
+func TestCRLFNormalizeStripsCR(t *testing.T) {
+	s, device := fakeDevice(t)
+
+	go device.Write([]byte("line one\r\nline two\r\n"))
+
+	match, err := s.Expect(regexp.MustCompile(`line one\nline two\n`), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(match) != "line one\nline two\n" {
+		t.Errorf("match = %q, want CR stripped", match)
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	s, device := fakeDevice(t)
+
+	go func() {
+		buf := make([]byte, 64)
+		device.Read(buf) // "reset\r\n"
+		device.Write([]byte("U-Boot> "))
+	}()
+
+	steps := []Step{
+		{Sleep: 10 * time.Millisecond},
+		{Send: "reset"},
+		{ExpectPrompt: regexp.MustCompile(`U-Boot> $`), Timeout: time.Second},
+	}
+	if err := s.Run(steps); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunScriptPropagatesError(t *testing.T) {
+	s, _ := fakeDevice(t)
+
+	steps := []Step{
+		{ExpectPrompt: regexp.MustCompile(`never`), Timeout: 20 * time.Millisecond},
+		{Send: "should not run"},
+	}
+	if err := s.Run(steps); err == nil {
+		t.Fatal("expected Run to stop at the failing step")
+	}
+}
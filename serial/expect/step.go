@@ -0,0 +1,55 @@
+package expect
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// DefaultStepTimeout is used for an ExpectPrompt step whose Timeout is zero.
+const DefaultStepTimeout = 5 * time.Second
+
+// Step is one directive in a scripted Run. Exactly one of Sleep, Send, or
+// ExpectPrompt should be set; Run executes them in order and stops at the
+// first error.
+type Step struct {
+	// Sleep pauses for the given duration before the next step.
+	Sleep time.Duration
+
+	// Send transmits a line, as Session.Send.
+	Send string
+
+	// ExpectPrompt waits for this pattern to appear, as Session.Expect, using
+	// Timeout (DefaultStepTimeout if zero).
+	ExpectPrompt *regexp.Regexp
+	Timeout      time.Duration
+}
+
+// Run executes steps against the session in order, stopping and returning
+// an error at the first step that fails.
+func (s *Session) Run(steps []Step) error {
+	for i, step := range steps {
+		switch {
+		case step.Sleep > 0:
+			time.Sleep(step.Sleep)
+
+		case step.Send != "":
+			if err := s.Send(step.Send); err != nil {
+				return fmt.Errorf("expect: step %d (send %q): %w", i, step.Send, err)
+			}
+
+		case step.ExpectPrompt != nil:
+			timeout := step.Timeout
+			if timeout == 0 {
+				timeout = DefaultStepTimeout
+			}
+			if _, err := s.Expect(step.ExpectPrompt, timeout); err != nil {
+				return fmt.Errorf("expect: step %d (expectPrompt %q): %w", i, step.ExpectPrompt, err)
+			}
+
+		default:
+			return fmt.Errorf("expect: step %d has no action", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package frame
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+const cobsDelimiter = 0x00
+
+type cobsConn struct {
+	rwc io.ReadWriteCloser
+	r   *bufio.Reader
+}
+
+// NewCOBS wraps rwc with Consistent Overhead Byte Stuffing framing:
+// payloads are COBS-encoded and delimited by a single 0x00 byte.
+func NewCOBS(rwc io.ReadWriteCloser) PacketConn {
+	return &cobsConn{rwc: rwc, r: bufio.NewReader(rwc)}
+}
+
+func (c *cobsConn) Close() error { return c.rwc.Close() }
+
+func (c *cobsConn) WritePacket(payload []byte) error {
+	encoded := cobsEncode(payload)
+	encoded = append(encoded, cobsDelimiter)
+	_, err := c.rwc.Write(encoded)
+	return err
+}
+
+func (c *cobsConn) ReadPacket() ([]byte, error) {
+	for {
+		raw, err := c.r.ReadBytes(cobsDelimiter)
+		if err != nil {
+			return nil, err
+		}
+		encoded := raw[:len(raw)-1] // drop the trailing delimiter itself
+		if len(encoded) == 0 {
+			continue // back-to-back delimiters: an empty frame, keep reading
+		}
+
+		packet, err := cobsDecode(encoded)
+		if err != nil {
+			// Corrupted framing: drop this frame and resynchronize on the
+			// next delimiter.
+			continue
+		}
+		return packet, nil
+	}
+}
+
+// cobsEncode implements the standard COBS algorithm: data is split into
+// blocks of up to 254 non-zero bytes, each prefixed by a code byte giving
+// the distance to the next zero (0xFF meaning a full block of 254 bytes with
+// no zero after it).
+func cobsEncode(data []byte) []byte {
+	out := make([]byte, 0, len(data)+len(data)/254+2)
+	codeIdx := 0
+	out = append(out, 0)
+	code := byte(1)
+
+	for _, b := range data {
+		if b == 0 {
+			out[codeIdx] = code
+			codeIdx = len(out)
+			out = append(out, 0)
+			code = 1
+			continue
+		}
+
+		out = append(out, b)
+		code++
+		if code == 0xFF {
+			out[codeIdx] = code
+			codeIdx = len(out)
+			out = append(out, 0)
+			code = 1
+		}
+	}
+	out[codeIdx] = code
+
+	return out
+}
+
+// cobsDecode reverses cobsEncode. encoded must not include the trailing
+// 0x00 packet delimiter.
+func cobsDecode(encoded []byte) ([]byte, error) {
+	out := make([]byte, 0, len(encoded))
+	i := 0
+	for i < len(encoded) {
+		code := encoded[i]
+		if code == 0 {
+			return nil, errors.New("frame: cobs block has a zero code byte")
+		}
+		i++
+
+		blockLen := int(code) - 1
+		if i+blockLen > len(encoded) {
+			return nil, errors.New("frame: cobs block overruns the packet")
+		}
+		out = append(out, encoded[i:i+blockLen]...)
+		i += blockLen
+
+		if code < 0xFF && i < len(encoded) {
+			out = append(out, 0)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCOBSEncode(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		want []byte
+	}{
+		{[]byte{0x00}, []byte{0x01, 0x01}},
+		{[]byte{0x00, 0x00}, []byte{0x01, 0x01, 0x01}},
+		{[]byte{0x00, 0x11, 0x00}, []byte{0x01, 0x02, 0x11, 0x01}},
+		{[]byte{0x11, 0x22, 0x00, 0x33}, []byte{0x03, 0x11, 0x22, 0x02, 0x33}},
+		{[]byte{0x11, 0x22, 0x33, 0x44}, []byte{0x05, 0x11, 0x22, 0x33, 0x44}},
+		{[]byte{0x11, 0x22, 0x33, 0x44, 0x00}, []byte{0x05, 0x11, 0x22, 0x33, 0x44, 0x01}},
+	}
+
+	for _, tc := range testCases {
+		got := cobsEncode(tc.data)
+		if !bytes.Equal(got, tc.want) {
+			t.Errorf("cobsEncode(%#v) = %#v, want %#v", tc.data, got, tc.want)
+		}
+	}
+}
+
+func TestCOBS254ByteBlock(t *testing.T) {
+	data := make([]byte, 254)
+	for i := range data {
+		data[i] = byte(i + 1) // no zero bytes
+	}
+
+	encoded := cobsEncode(data)
+	if encoded[0] != 0xFF {
+		t.Fatalf("expected a 0xFF code byte for a full 254-byte block, got %#x", encoded[0])
+	}
+
+	decoded, err := cobsDecode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("round trip of a 254-byte block did not match")
+	}
+}
+
+func TestCOBSRoundTrip(t *testing.T) {
+	testCases := [][]byte{
+		nil,
+		{},
+		{0x00, 0x00, 0x00},
+		{0x01},
+		bytes.Repeat([]byte{0xAA, 0x00}, 200),
+	}
+
+	for _, data := range testCases {
+		encoded := cobsEncode(data)
+		decoded, err := cobsDecode(encoded)
+		if err != nil {
+			t.Fatalf("cobsDecode(cobsEncode(%#v)): %v", data, err)
+		}
+		if !bytes.Equal(decoded, data) && !(len(decoded) == 0 && len(data) == 0) {
+			t.Errorf("round trip of %#v produced %#v", data, decoded)
+		}
+	}
+}
+
+func TestCOBSDecodeRejectsCorruption(t *testing.T) {
+	// A zero code byte partway through is invalid.
+	if _, err := cobsDecode([]byte{0x02, 0x11, 0x00, 0x22}); err == nil {
+		t.Error("expected an error for a mid-packet zero code byte")
+	}
+	// A code byte claiming more bytes than remain is invalid.
+	if _, err := cobsDecode([]byte{0xFF, 0x11}); err == nil {
+		t.Error("expected an error for a block that overruns the packet")
+	}
+}
@@ -0,0 +1,21 @@
+// Package frame delivers whole messages over a serial port opened with
+// serial.Open, rather than the raw byte stream Read/Write otherwise give
+// you. It provides SLIP, COBS, and a simple length-prefixed codec, each
+// able to resynchronize after corrupted framing.
+package frame
+
+import "io"
+
+// PacketConn delivers discrete packets over an underlying byte stream,
+// handling whatever framing its implementation uses.
+type PacketConn interface {
+	// ReadPacket returns the next complete packet, blocking until one
+	// arrives. It resynchronizes past corrupted framing rather than
+	// returning a partial or garbled packet.
+	ReadPacket() ([]byte, error)
+
+	// WritePacket frames and writes a single packet.
+	WritePacket(payload []byte) error
+
+	io.Closer
+}
@@ -0,0 +1,104 @@
+package frame
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// lengthPrefixMaxPayload is the largest payload a uint16 length field can
+// describe.
+const lengthPrefixMaxPayload = 1<<16 - 1
+
+type lengthPrefixConn struct {
+	rwc     io.ReadWriteCloser
+	pending []byte
+}
+
+// NewLengthPrefixed wraps rwc with a simple
+// [length uint16 big-endian][payload][CRC16] framing.
+func NewLengthPrefixed(rwc io.ReadWriteCloser) PacketConn {
+	return &lengthPrefixConn{rwc: rwc}
+}
+
+func (c *lengthPrefixConn) Close() error { return c.rwc.Close() }
+
+func (c *lengthPrefixConn) WritePacket(payload []byte) error {
+	if len(payload) > lengthPrefixMaxPayload {
+		return fmt.Errorf("frame: payload of %d bytes exceeds the %d byte length-prefix limit", len(payload), lengthPrefixMaxPayload)
+	}
+
+	buf := make([]byte, 2, 2+len(payload)+2)
+	binary.BigEndian.PutUint16(buf, uint16(len(payload)))
+	buf = append(buf, payload...)
+	crc := crc16CCITT(buf)
+	buf = append(buf, byte(crc>>8), byte(crc))
+
+	_, err := c.rwc.Write(buf)
+	return err
+}
+
+// fill reads from the underlying stream until at least n bytes are
+// buffered in pending.
+func (c *lengthPrefixConn) fill(n int) error {
+	buf := make([]byte, 4096)
+	for len(c.pending) < n {
+		m, err := c.rwc.Read(buf)
+		if m > 0 {
+			c.pending = append(c.pending, buf[:m]...)
+		}
+		// io.Reader permits returning n > 0 alongside a non-nil error
+		// (e.g. io.EOF) in the same call; only surface the error once
+		// those bytes still leave us short, so a fully-buffered packet
+		// that arrives in the same Read as EOF isn't discarded.
+		if err != nil && len(c.pending) < n {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *lengthPrefixConn) ReadPacket() ([]byte, error) {
+	for {
+		if err := c.fill(2); err != nil {
+			return nil, err
+		}
+		length := int(binary.BigEndian.Uint16(c.pending))
+		total := 2 + length + 2
+
+		if err := c.fill(total); err != nil {
+			return nil, err
+		}
+
+		frame := c.pending[:total]
+		payload := frame[2 : 2+length]
+		wantCRC := binary.BigEndian.Uint16(frame[2+length:])
+		if crc16CCITT(frame[:2+length]) == wantCRC {
+			out := append([]byte(nil), payload...)
+			c.pending = c.pending[total:]
+			return out, nil
+		}
+
+		// The length field we parsed was almost certainly garbage from a
+		// lost synchronization point; drop one byte and look for the next
+		// valid frame starting there.
+		c.pending = c.pending[1:]
+	}
+}
+
+// crc16CCITT computes CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF) over
+// data.
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = (crc << 1) ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
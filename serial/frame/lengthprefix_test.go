@@ -0,0 +1,114 @@
+package frame
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	// The standard CRC-16/CCITT-FALSE check value.
+	if got := crc16CCITT([]byte("123456789")); got != 0x29B1 {
+		t.Errorf("crc16CCITT(\"123456789\") = %#x, want 0x29b1", got)
+	}
+}
+
+func TestLengthPrefixRoundTrip(t *testing.T) {
+	conn := NewLengthPrefixed(newLoopback())
+	defer conn.Close()
+
+	packets := [][]byte{
+		{0x01, 0x02, 0x03},
+		{},
+		bytes.Repeat([]byte{0xAB}, 500),
+	}
+
+	go func() {
+		for _, p := range packets {
+			conn.WritePacket(p)
+		}
+	}()
+
+	for _, want := range packets {
+		got, err := conn.ReadPacket()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("ReadPacket() = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestLengthPrefixResyncsPastCorruption(t *testing.T) {
+	conn := NewLengthPrefixed(newLoopback()).(*lengthPrefixConn)
+	defer conn.Close()
+
+	good := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+
+	go func() {
+		// A single garbage byte ahead of a well-formed frame should be
+		// skipped until the real frame is found.
+		conn.rwc.Write([]byte{0x00})
+		conn.WritePacket(good)
+	}()
+
+	got, err := conn.ReadPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, good) {
+		t.Errorf("ReadPacket() = %#v, want %#v after resync", got, good)
+	}
+}
+
+// eofWithData is an io.Reader that hands back its entire buffer and io.EOF
+// from the same Read call, which io.Reader permits but io.Pipe (used by
+// every other test in this file) never actually does.
+type eofWithData struct {
+	data []byte
+	done bool
+}
+
+func (r *eofWithData) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	r.done = true
+	return copy(p, r.data), io.EOF
+}
+
+type eofWithDataConn struct {
+	*eofWithData
+}
+
+func (eofWithDataConn) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+func (eofWithDataConn) Close() error              { return nil }
+
+// TestLengthPrefixReadsPacketDeliveredWithEOF verifies fill() doesn't
+// discard a fully-buffered packet just because the Read call that supplied
+// its last bytes also returned an error.
+func TestLengthPrefixReadsPacketDeliveredWithEOF(t *testing.T) {
+	want := []byte{0x01, 0x02, 0x03}
+
+	probe := NewLengthPrefixed(newLoopback()).(*lengthPrefixConn)
+	go probe.WritePacket(want)
+	frame := make([]byte, 4096)
+	n, err := probe.rwc.Read(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame = frame[:n]
+	probe.Close()
+
+	conn := NewLengthPrefixed(eofWithDataConn{&eofWithData{data: frame}}).(*lengthPrefixConn)
+	defer conn.Close()
+
+	got, err := conn.ReadPacket()
+	if err != nil {
+		t.Fatalf("ReadPacket() = _, %v, want the buffered packet with no error", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ReadPacket() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package frame
+
+import (
+	"bufio"
+	"io"
+)
+
+// RFC 1055 SLIP special bytes.
+const (
+	slipEnd    = 0xC0
+	slipEsc    = 0xDB
+	slipEscEnd = 0xDC
+	slipEscEsc = 0xDD
+)
+
+type slipConn struct {
+	rwc io.ReadWriteCloser
+	r   *bufio.Reader
+}
+
+// NewSLIP wraps rwc with RFC 1055 SLIP framing: payloads are byte-stuffed
+// and delimited by 0xC0.
+func NewSLIP(rwc io.ReadWriteCloser) PacketConn {
+	return &slipConn{rwc: rwc, r: bufio.NewReader(rwc)}
+}
+
+func (c *slipConn) Close() error { return c.rwc.Close() }
+
+func (c *slipConn) WritePacket(payload []byte) error {
+	buf := make([]byte, 0, len(payload)+2)
+	// A leading END flushes out any garbage left on the wire from a
+	// previous corrupted frame, per the RFC 1055 recommendation.
+	buf = append(buf, slipEnd)
+	for _, b := range payload {
+		switch b {
+		case slipEnd:
+			buf = append(buf, slipEsc, slipEscEnd)
+		case slipEsc:
+			buf = append(buf, slipEsc, slipEscEsc)
+		default:
+			buf = append(buf, b)
+		}
+	}
+	buf = append(buf, slipEnd)
+
+	_, err := c.rwc.Write(buf)
+	return err
+}
+
+func (c *slipConn) ReadPacket() ([]byte, error) {
+	for {
+		packet, err := c.readOneFrame()
+		if err != nil {
+			return nil, err
+		}
+		if len(packet) > 0 {
+			return packet, nil
+		}
+		// Back-to-back END bytes (our own leading END, or line noise)
+		// produce an empty frame; just keep reading.
+	}
+}
+
+// readOneFrame reads up to and including the next END byte, unescaping as
+// it goes. An invalid escape sequence resynchronizes by discarding
+// everything read so far for this frame and continuing from whatever comes
+// after the next END.
+func (c *slipConn) readOneFrame() ([]byte, error) {
+	var out []byte
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch b {
+		case slipEnd:
+			return out, nil
+
+		case slipEsc:
+			next, err := c.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			switch next {
+			case slipEscEnd:
+				out = append(out, slipEnd)
+			case slipEscEsc:
+				out = append(out, slipEsc)
+			default:
+				if err := c.discardUntilEnd(); err != nil {
+					return nil, err
+				}
+				out = out[:0]
+			}
+
+		default:
+			out = append(out, b)
+		}
+	}
+}
+
+func (c *slipConn) discardUntilEnd() error {
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b == slipEnd {
+			return nil
+		}
+	}
+}
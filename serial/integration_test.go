@@ -83,6 +83,9 @@ func TestIncrementAndEcho(t *testing.T) {
 	options.DataBits = 8
 	options.StopBits = 1
 	options.MinimumReadSize = 4
+	// Hold DTR low on open instead of sleeping through the Arduino's
+	// auto-reset; see ModemControl and OpenOptions.InitialDTR.
+	options.InitialDTR = Low
 
 	circuit, err := Open(options)
 	if err != nil {
@@ -91,9 +94,6 @@ func TestIncrementAndEcho(t *testing.T) {
 
 	defer circuit.Close()
 
-	// Pause for a few seconds to deal with the Arduino's annoying startup delay.
-	time.Sleep(3e9)
-
 	// Write some bytes.
 	b := []byte{0x00, 0x17, 0xFE, 0xFF}
 
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package serial
+
+import "syscall"
+
+// ioctl is the raw ioctl(2) syscall, shared by the linux and darwin port
+// configuration code and by the modem control lines in modem_posix.go.
+func ioctl(fd uintptr, req uintptr, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
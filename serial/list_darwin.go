@@ -0,0 +1,145 @@
+//go:build darwin
+
+package serial
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <IOKit/IOKitLib.h>
+#include <IOKit/serial/IOSerialKeys.h>
+#include <CoreFoundation/CoreFoundation.h>
+#include <stdlib.h>
+
+// cfStringToC copies a CFStringRef into a freshly malloc'd C string, or
+// returns NULL if ref is NULL or not a string.
+static char *cfStringToC(CFTypeRef ref) {
+	if (ref == NULL || CFGetTypeID(ref) != CFStringGetTypeID()) {
+		return NULL;
+	}
+	CFStringRef s = (CFStringRef)ref;
+	CFIndex length = CFStringGetLength(s);
+	CFIndex maxSize = CFStringGetMaximumSizeForEncoding(length, kCFStringEncodingUTF8) + 1;
+	char *buf = malloc(maxSize);
+	if (!CFStringGetCString(s, buf, maxSize, kCFStringEncodingUTF8)) {
+		free(buf);
+		return NULL;
+	}
+	return buf;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// List enumerates IOSerialBSDClient services via IOKit, walking each one's
+// parent chain to its owning USB device (if any) for vendor/product
+// metadata.
+func List() []PortInfo {
+	matching := C.IOServiceMatching(C.kIOSerialBSDServiceValue)
+	if matching == 0 {
+		return nil
+	}
+
+	var iter C.io_iterator_t
+	if C.IOServiceGetMatchingServices(C.kIOMasterPortDefault, matching, &iter) != C.KERN_SUCCESS {
+		return nil
+	}
+	defer C.IOObjectRelease(C.io_object_t(iter))
+
+	var ports []PortInfo
+	for {
+		service := C.IOIteratorNext(iter)
+		if service == 0 {
+			break
+		}
+
+		name := cfStringProperty(service, C.CFSTR("IOCalloutDevice"))
+		if name != "" {
+			ports = append(ports, portInfoFromIOKitService(service, name))
+		}
+		C.IOObjectRelease(service)
+	}
+	return ports
+}
+
+// portInfoFromIOKitService fills in USB metadata by walking up service's
+// ancestry to the nearest IOUSBDevice, the way System Information does.
+func portInfoFromIOKitService(service C.io_object_t, name string) PortInfo {
+	info := PortInfo{Name: name}
+
+	usbService := findUSBAncestor(service)
+	if usbService == 0 {
+		return info
+	}
+	defer C.IOObjectRelease(usbService)
+
+	info.VID = hexProperty(usbService, C.CFSTR("idVendor"))
+	info.PID = hexProperty(usbService, C.CFSTR("idProduct"))
+	info.Manufacturer = cfStringProperty(usbService, C.CFSTR("USB Vendor Name"))
+	info.Product = cfStringProperty(usbService, C.CFSTR("USB Product Name"))
+	info.SerialNumber = cfStringProperty(usbService, C.CFSTR("USB Serial Number"))
+	info.IsUSB = info.VID != ""
+	info.Description = info.Product
+
+	return info
+}
+
+// findUSBAncestor walks up the IOKit registry from service looking for an
+// IOUSBDevice, returning 0 if the chain is exhausted first (e.g. for a
+// built-in, non-USB serial port).
+func findUSBAncestor(service C.io_object_t) C.io_object_t {
+	usbClass := C.CString("IOUSBDevice")
+	defer C.free(unsafe.Pointer(usbClass))
+
+	current := service
+	for i := 0; i < 8; i++ {
+		var parent C.io_object_t
+		if C.IORegistryEntryGetParentEntry(current, C.kIOServicePlane, &parent) != C.KERN_SUCCESS {
+			return 0
+		}
+		if current != service {
+			C.IOObjectRelease(current)
+		}
+		if C.IOObjectConformsTo(parent, usbClass) != 0 {
+			return parent
+		}
+		current = parent
+	}
+	return 0
+}
+
+// cfStringProperty reads the named registry property of service and returns
+// it as a Go string, or "" if it is absent or not a CFString.
+func cfStringProperty(service C.io_object_t, key C.CFStringRef) string {
+	ref := C.IORegistryEntryCreateCFProperty(service, key, C.kCFAllocatorDefault, 0)
+	if ref == 0 {
+		return ""
+	}
+	defer C.CFRelease(ref)
+
+	cstr := C.cfStringToC(ref)
+	if cstr == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cstr))
+	return C.GoString(cstr)
+}
+
+// hexProperty reads the named registry property of service, interprets it
+// as a CFNumber, and formats it as four lowercase hex digits (the
+// conventional rendering of a USB VID/PID).
+func hexProperty(service C.io_object_t, key C.CFStringRef) string {
+	ref := C.IORegistryEntryCreateCFProperty(service, key, C.kCFAllocatorDefault, 0)
+	if ref == 0 {
+		return ""
+	}
+	defer C.CFRelease(ref)
+
+	var v C.SInt32
+	if C.CFNumberGetValue(C.CFNumberRef(ref), C.kCFNumberSInt32Type, unsafe.Pointer(&v)) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04x", uint16(v))
+}
@@ -0,0 +1,80 @@
+//go:build linux
+
+package serial
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// List returns the serial ports visible under /sys/class/tty, with metadata
+// read from each entry's device subdirectory where available.
+func List() []PortInfo {
+	entries, err := os.ReadDir("/sys/class/tty")
+	if err != nil {
+		return nil
+	}
+
+	var ports []PortInfo
+	for _, entry := range entries {
+		devPath := "/dev/" + entry.Name()
+		if _, err := os.Stat(devPath); err != nil {
+			continue
+		}
+
+		sysPath := filepath.Join("/sys/class/tty", entry.Name(), "device")
+		if _, err := os.Lstat(sysPath); err != nil {
+			continue // virtual ttys (e.g. /dev/tty1) have no backing device
+		}
+
+		ports = append(ports, portInfoFromSysfs(devPath, sysPath))
+	}
+	return ports
+}
+
+// portInfoFromSysfs populates a PortInfo for the tty at devPath by walking
+// up from its /sys/class/tty/*/device symlink to the nearest USB device
+// directory, the way udev itself does.
+func portInfoFromSysfs(devPath, sysPath string) PortInfo {
+	info := PortInfo{Name: devPath}
+
+	usbDir := sysPath
+	for i := 0; i < 8; i++ {
+		if readSysfsAttr(usbDir, "idVendor") != "" {
+			break
+		}
+		parent, err := filepath.EvalSymlinks(filepath.Join(usbDir, ".."))
+		if err != nil || parent == usbDir {
+			usbDir = ""
+			break
+		}
+		usbDir = parent
+	}
+
+	if usbDir == "" {
+		info.Description = entryName(devPath)
+		return info
+	}
+
+	info.VID = readSysfsAttr(usbDir, "idVendor")
+	info.PID = readSysfsAttr(usbDir, "idProduct")
+	info.Manufacturer = readSysfsAttr(usbDir, "manufacturer")
+	info.Product = readSysfsAttr(usbDir, "product")
+	info.SerialNumber = readSysfsAttr(usbDir, "serial")
+	info.IsUSB = info.VID != ""
+	info.Description = strings.TrimSpace(info.Manufacturer + " " + info.Product)
+	return info
+}
+
+func readSysfsAttr(dir, attr string) string {
+	b, err := os.ReadFile(filepath.Join(dir, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func entryName(devPath string) string {
+	return filepath.Base(devPath)
+}
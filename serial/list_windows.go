@@ -0,0 +1,135 @@
+//go:build windows
+
+package serial
+
+import (
+	"regexp"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	setupapi = syscall.NewLazyDLL("setupapi.dll")
+
+	procSetupDiGetClassDevsW              = setupapi.NewProc("SetupDiGetClassDevsW")
+	procSetupDiEnumDeviceInfo             = setupapi.NewProc("SetupDiEnumDeviceInfo")
+	procSetupDiGetDeviceRegistryPropertyW = setupapi.NewProc("SetupDiGetDeviceRegistryPropertyW")
+	procSetupDiGetDeviceInstanceIdW  = setupapi.NewProc("SetupDiGetDeviceInstanceIdW")
+	procSetupDiDestroyDeviceInfoList = setupapi.NewProc("SetupDiDestroyDeviceInfoList")
+)
+
+// GUID_DEVCLASS_PORTS, from devguid.h.
+var guidDevClassPorts = syscall.GUID{
+	Data1: 0x4d36e978,
+	Data2: 0xe325,
+	Data3: 0x11ce,
+	Data4: [8]byte{0xbf, 0xc1, 0x08, 0x00, 0x2b, 0xe1, 0x03, 0x18},
+}
+
+const (
+	digcfPresent         = 0x00000002
+	digcfDeviceInterface = 0x00000010
+
+	sprDevicedesc   = 0x00000000
+	sprMfg          = 0x0000000B
+	sprFriendlyname = 0x0000000C
+)
+
+var comPortRE = regexp.MustCompile(`\(COM\d+\)`)
+
+// List enumerates the Windows "Ports (COM & LPT)" device class via
+// SetupDiGetClassDevs and reads each device's friendly name, description,
+// manufacturer, and (if it's a USB device) VID/PID out of its instance ID.
+func List() []PortInfo {
+	set, _, _ := procSetupDiGetClassDevsW.Call(
+		uintptr(unsafe.Pointer(&guidDevClassPorts)),
+		0,
+		0,
+		uintptr(digcfPresent),
+	)
+	if set == 0 || set == ^uintptr(0) {
+		return nil
+	}
+	defer procSetupDiDestroyDeviceInfoList.Call(set)
+
+	type spDevinfoData struct {
+		cbSize    uint32
+		classGUID syscall.GUID
+		devInst   uint32
+		reserved  uintptr
+	}
+
+	var ports []PortInfo
+	for i := uint32(0); ; i++ {
+		data := spDevinfoData{cbSize: uint32(unsafe.Sizeof(spDevinfoData{}))}
+		ok, _, _ := procSetupDiEnumDeviceInfo.Call(set, uintptr(i), uintptr(unsafe.Pointer(&data)))
+		if ok == 0 {
+			break
+		}
+
+		friendly := regProperty(set, unsafe.Pointer(&data), sprFriendlyname)
+		name := comPortRE.FindString(friendly)
+		name = strings.Trim(name, "()")
+		if name == "" {
+			continue // not all "Ports" class devices are actually COM ports
+		}
+
+		info := PortInfo{
+			Name:         name,
+			Description:  regProperty(set, unsafe.Pointer(&data), sprDevicedesc),
+			Manufacturer: regProperty(set, unsafe.Pointer(&data), sprMfg),
+		}
+		if instanceID := deviceInstanceID(set, unsafe.Pointer(&data)); strings.HasPrefix(instanceID, "USB\\") {
+			info.IsUSB = true
+			info.VID, info.PID = parseUSBInstanceID(instanceID)
+		}
+		ports = append(ports, info)
+	}
+	return ports
+}
+
+// regProperty reads one of the SPDRP_* string properties for data.
+func regProperty(set uintptr, data unsafe.Pointer, property uint32) string {
+	var buf [512]uint16
+	ok, _, _ := procSetupDiGetDeviceRegistryPropertyW.Call(
+		set,
+		uintptr(data),
+		uintptr(property),
+		0,
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)*2),
+		0,
+	)
+	if ok == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:])
+}
+
+// deviceInstanceID returns the PnP device instance ID, e.g.
+// "USB\VID_2341&PID_0043\5573931313935161A162".
+func deviceInstanceID(set uintptr, data unsafe.Pointer) string {
+	var buf [512]uint16
+	ok, _, _ := procSetupDiGetDeviceInstanceIdW.Call(
+		set,
+		uintptr(data),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)),
+		0,
+	)
+	if ok == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:])
+}
+
+var usbInstanceIDRE = regexp.MustCompile(`VID_([0-9A-Fa-f]{4})&PID_([0-9A-Fa-f]{4})`)
+
+func parseUSBInstanceID(instanceID string) (vid, pid string) {
+	m := usbInstanceIDRE.FindStringSubmatch(instanceID)
+	if m == nil {
+		return "", ""
+	}
+	return strings.ToLower(m[1]), strings.ToLower(m[2])
+}
@@ -0,0 +1,150 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modbus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/longchiwen/go-serial/serial"
+)
+
+// asciiTransport implements ASCII framing: a ':' start delimiter, the PDU
+// (plus slave ID and LRC) hex-encoded in upper case, and a "\r\n" end
+// delimiter.
+type asciiTransport struct {
+	port    io.ReadWriteCloser
+	slaveID byte
+	timeout time.Duration
+	lines   chan asciiLine
+}
+
+type asciiLine struct {
+	line []byte
+	err  error
+}
+
+// NewASCIIClientHandler opens opts.PortName and returns a Client that speaks
+// Modbus ASCII framing to the slave identified by slaveID.
+func NewASCIIClientHandler(opts serial.OpenOptions, slaveID byte) (Client, error) {
+	port, err := serial.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &asciiTransport{
+		port:    port,
+		slaveID: slaveID,
+		timeout: defaultTimeout,
+		lines:   make(chan asciiLine, 16),
+	}
+	go t.readLoop()
+
+	return newClient(t), nil
+}
+
+// readLoop owns the only reads against the underlying bufio.Reader for the
+// lifetime of the transport. A single long-lived reader, rather than one
+// goroutine per Send, means a Send that times out never leaves a goroutine
+// blocked inside ReadBytes, and two Sends never race each other over the
+// same bufio.Reader, which is not safe for concurrent use.
+func (t *asciiTransport) readLoop() {
+	r := bufio.NewReader(t.port)
+	for {
+		if _, err := r.ReadBytes(':'); err != nil {
+			t.lines <- asciiLine{err: err}
+			return
+		}
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			t.lines <- asciiLine{err: err}
+			return
+		}
+		t.lines <- asciiLine{line: bytes.TrimRight(line, "\r\n")}
+	}
+}
+
+// drainStale discards any line still buffered from a previous Send that
+// timed out, so it isn't mistaken for the response to the next request.
+func (t *asciiTransport) drainStale() {
+	for {
+		select {
+		case <-t.lines:
+		default:
+			return
+		}
+	}
+}
+
+func (t *asciiTransport) Close() error {
+	return t.port.Close()
+}
+
+func (t *asciiTransport) Send(pdu []byte) ([]byte, error) {
+	frame := make([]byte, 0, len(pdu)+2)
+	frame = append(frame, t.slaveID)
+	frame = append(frame, pdu...)
+	frame = append(frame, lrc(frame))
+
+	line := make([]byte, 0, 2*len(frame)+3)
+	line = append(line, ':')
+	line = append(line, []byte(strings.ToUpper(hex.EncodeToString(frame)))...)
+	line = append(line, '\r', '\n')
+
+	t.drainStale()
+	if _, err := t.port.Write(line); err != nil {
+		return nil, fmt.Errorf("modbus: ascii write: %w", err)
+	}
+
+	resp, err := t.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(string(resp))
+	if err != nil {
+		return nil, fmt.Errorf("modbus: ascii response is not valid hex: %w", err)
+	}
+	if len(raw) < 3 {
+		return nil, errors.New("modbus: ascii response too short")
+	}
+
+	n := len(raw)
+	if lrc(raw[:n-1]) != raw[n-1] {
+		return nil, errors.New("modbus: ascii response failed LRC check")
+	}
+	if raw[0] != t.slaveID {
+		return nil, fmt.Errorf("modbus: ascii response slave ID %d does not match request %d", raw[0], t.slaveID)
+	}
+
+	return checkException(raw[1 : n-1])
+}
+
+// readLine waits for the next line readLoop has already parsed past the
+// ':' start delimiter, up to (but not including) the closing "\r\n".
+func (t *asciiTransport) readLine() ([]byte, error) {
+	select {
+	case r := <-t.lines:
+		return r.line, r.err
+	case <-time.After(t.timeout):
+		return nil, errors.New("modbus: timed out waiting for ascii response")
+	}
+}
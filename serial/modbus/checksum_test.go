@@ -0,0 +1,89 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modbus
+
+import "testing"
+
+func TestCRC16(t *testing.T) {
+	testCases := []struct {
+		data    []byte
+		wantLo  byte
+		wantHi  byte
+	}{
+		// Read holding registers, slave 1, address 0, quantity 10.
+		{[]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A}, 0xC5, 0xCD},
+		// The worked example from the Modbus application protocol spec.
+		{[]byte{0x11, 0x03, 0x00, 0x6B, 0x00, 0x03}, 0x76, 0x87},
+	}
+
+	for _, tc := range testCases {
+		crc := crc16(tc.data)
+		gotLo, gotHi := byte(crc), byte(crc>>8)
+		if gotLo != tc.wantLo || gotHi != tc.wantHi {
+			t.Errorf("crc16(%#v) = (%#x, %#x), want (%#x, %#x)", tc.data, gotLo, gotHi, tc.wantLo, tc.wantHi)
+		}
+	}
+}
+
+func TestLRC(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		want byte
+	}{
+		// Read holding registers, slave 1, address 0, quantity 1.
+		{[]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x01}, 0xFB},
+		// Write single register, slave 2, address 1, value 3.
+		{[]byte{0x02, 0x06, 0x00, 0x01, 0x00, 0x03}, 0xF4},
+	}
+
+	for _, tc := range testCases {
+		if got := lrc(tc.data); got != tc.want {
+			t.Errorf("lrc(%#v) = %#x, want %#x", tc.data, got, tc.want)
+		}
+	}
+
+	// The LRC is defined so that appending it to the message makes the sum
+	// of all bytes (mod 256) zero.
+	msg := []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x01}
+	checksum := lrc(msg)
+	var sum byte
+	for _, b := range append(msg, checksum) {
+		sum += b
+	}
+	if sum != 0 {
+		t.Errorf("message+LRC summed to %#x, want 0", sum)
+	}
+}
+
+func TestRTUFrameGaps(t *testing.T) {
+	testCases := []struct {
+		baudRate       uint
+		wantFrameDelay int64 // nanoseconds
+	}{
+		{19200, 1750000},
+		{115200, 1750000},
+		{9600, 4010416}, // 3.5 * 11 / 9600 seconds, truncated to whole ns
+	}
+
+	for _, tc := range testCases {
+		frameDelay, _ := rtuFrameGaps(tc.baudRate)
+		// Allow a little slack for integer division rounding at low baud
+		// rates.
+		diff := int64(frameDelay) - tc.wantFrameDelay
+		if diff < -1000 || diff > 1000 {
+			t.Errorf("rtuFrameGaps(%d) frameDelay = %v, want ~%dns", tc.baudRate, frameDelay, tc.wantFrameDelay)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+func (c *client) ReadCoils(address, quantity uint16) ([]bool, error) {
+	return c.readBits(FuncCodeReadCoils, address, quantity)
+}
+
+func (c *client) ReadDiscreteInputs(address, quantity uint16) ([]bool, error) {
+	return c.readBits(FuncCodeReadDiscreteInputs, address, quantity)
+}
+
+func (c *client) readBits(funcCode byte, address, quantity uint16) ([]bool, error) {
+	pdu := putUint16(putUint16([]byte{funcCode}, address), quantity)
+	resp, err := c.t.Send(pdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 || int(resp[1]) != len(resp)-2 {
+		return nil, errors.New("modbus: malformed read response")
+	}
+	if wantBytes := (int(quantity) + 7) / 8; int(resp[1]) < wantBytes {
+		return nil, errors.New("modbus: read response byte count too small for requested quantity")
+	}
+	return bytesToBools(resp[2:], int(quantity)), nil
+}
+
+func (c *client) ReadHoldingRegisters(address, quantity uint16) ([]uint16, error) {
+	return c.readRegisters(FuncCodeReadHoldingRegisters, address, quantity)
+}
+
+func (c *client) ReadInputRegisters(address, quantity uint16) ([]uint16, error) {
+	return c.readRegisters(FuncCodeReadInputRegisters, address, quantity)
+}
+
+func (c *client) readRegisters(funcCode byte, address, quantity uint16) ([]uint16, error) {
+	pdu := putUint16(putUint16([]byte{funcCode}, address), quantity)
+	resp, err := c.t.Send(pdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 || int(resp[1]) != len(resp)-2 || resp[1]%2 != 0 {
+		return nil, errors.New("modbus: malformed read response")
+	}
+	if int(resp[1]) != 2*int(quantity) {
+		return nil, errors.New("modbus: read response byte count does not match requested quantity")
+	}
+	out := make([]uint16, quantity)
+	for i := range out {
+		out[i] = binary.BigEndian.Uint16(resp[2+2*i:])
+	}
+	return out, nil
+}
+
+func (c *client) WriteSingleCoil(address uint16, value bool) error {
+	v := uint16(0x0000)
+	if value {
+		v = 0xFF00
+	}
+	pdu := putUint16(putUint16([]byte{FuncCodeWriteSingleCoil}, address), v)
+	_, err := c.t.Send(pdu)
+	return err
+}
+
+func (c *client) WriteSingleRegister(address, value uint16) error {
+	pdu := putUint16(putUint16([]byte{FuncCodeWriteSingleRegister}, address), value)
+	_, err := c.t.Send(pdu)
+	return err
+}
+
+func (c *client) WriteMultipleCoils(address uint16, values []bool) error {
+	data := boolsToBytes(values)
+	pdu := putUint16(putUint16([]byte{FuncCodeWriteMultipleCoils}, address), uint16(len(values)))
+	pdu = append(pdu, byte(len(data)))
+	pdu = append(pdu, data...)
+	_, err := c.t.Send(pdu)
+	return err
+}
+
+func (c *client) WriteMultipleRegisters(address uint16, values []uint16) error {
+	pdu := putUint16(putUint16([]byte{FuncCodeWriteMultipleRegisters}, address), uint16(len(values)))
+	pdu = append(pdu, byte(len(values)*2))
+	for _, v := range values {
+		pdu = putUint16(pdu, v)
+	}
+	_, err := c.t.Send(pdu)
+	return err
+}
+
+func (c *client) Diagnostics(subFunction, data uint16) (uint16, error) {
+	pdu := putUint16(putUint16([]byte{FuncCodeDiagnostics}, subFunction), data)
+	resp, err := c.t.Send(pdu)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp) < 5 {
+		return 0, errors.New("modbus: malformed diagnostics response")
+	}
+	return binary.BigEndian.Uint16(resp[3:5]), nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modbus
+
+// putUint16 appends the big-endian encoding of v to dst and returns the
+// extended slice, in the style of encoding/binary's AppendUint16.
+func putUint16(dst []byte, v uint16) []byte {
+	return append(dst, byte(v>>8), byte(v))
+}
+
+// bytesToBools unpacks the first n bits of data, LSB first within each byte,
+// as used by the coil and discrete input responses.
+func bytesToBools(data []byte, n int) []bool {
+	out := make([]bool, n)
+	for i := 0; i < n; i++ {
+		out[i] = data[i/8]&(1<<uint(i%8)) != 0
+	}
+	return out
+}
+
+// boolsToBytes packs values into bits, LSB first within each byte, as
+// required by the write-multiple-coils request.
+func boolsToBytes(values []bool) []byte {
+	out := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			out[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return out
+}
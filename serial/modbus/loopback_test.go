@@ -0,0 +1,64 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Integration test for the modbus package. Like serial's TestLoopback, it is
+// only meaningful against a slave simulator (or real device) reachable via
+// SERIAL_PORT, and is skipped otherwise.
+
+package modbus
+
+import (
+	"os"
+	"testing"
+
+	"github.com/longchiwen/go-serial/serial"
+)
+
+// TestRTULoopback drives a Modbus RTU slave simulator listening on
+// SERIAL_PORT: it writes a holding register and reads it back.
+func TestRTULoopback(t *testing.T) {
+	device := os.Getenv("SERIAL_PORT")
+	if device == "" {
+		t.Skip("SERIAL_PORT not set; skipping modbus RTU loopback test")
+	}
+
+	var options serial.OpenOptions
+	options.PortName = device
+	options.BaudRate = 19200
+	options.DataBits = 8
+	options.StopBits = 1
+	options.MinimumReadSize = 1
+
+	const slaveID = 0x01
+	client, err := NewRTUClientHandler(options, slaveID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	const address = 0x0000
+	const value = 0x2A
+
+	if err := client.WriteSingleRegister(address, value); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.ReadHoldingRegisters(address, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != value {
+		t.Errorf("ReadHoldingRegisters = %v, want [%#x]", got, value)
+	}
+}
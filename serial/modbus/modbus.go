@@ -0,0 +1,102 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modbus layers Modbus RTU and ASCII framing on top of a port opened
+// with serial.Open, for talking to PLCs, sensors, and similar field devices.
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Function codes for the Modbus requests supported by Client.
+const (
+	FuncCodeReadCoils              = 0x01
+	FuncCodeReadDiscreteInputs     = 0x02
+	FuncCodeReadHoldingRegisters   = 0x03
+	FuncCodeReadInputRegisters     = 0x04
+	FuncCodeWriteSingleCoil        = 0x05
+	FuncCodeWriteSingleRegister    = 0x06
+	FuncCodeDiagnostics            = 0x08
+	FuncCodeWriteMultipleCoils     = 0x0F
+	FuncCodeWriteMultipleRegisters = 0x10
+)
+
+// Client is a Modbus master bound to a single slave. It is implemented by
+// both the RTU and ASCII transports, so callers can switch framing without
+// changing call sites.
+type Client interface {
+	io.Closer
+
+	ReadCoils(address, quantity uint16) ([]bool, error)
+	ReadDiscreteInputs(address, quantity uint16) ([]bool, error)
+	ReadHoldingRegisters(address, quantity uint16) ([]uint16, error)
+	ReadInputRegisters(address, quantity uint16) ([]uint16, error)
+	WriteSingleCoil(address uint16, value bool) error
+	WriteSingleRegister(address, value uint16) error
+	WriteMultipleCoils(address uint16, values []bool) error
+	WriteMultipleRegisters(address uint16, values []uint16) error
+
+	// Diagnostics issues function code 0x08 with the given sub-function and
+	// echo data, returning the echoed data from the slave.
+	Diagnostics(subFunction, data uint16) (uint16, error)
+}
+
+// transport is the framing-specific half of a Client: it knows how to wrap a
+// PDU (function code plus data, with no slave ID or checksum) for the wire
+// and how to strip the framing back off the response.
+type transport interface {
+	io.Closer
+	Send(pdu []byte) ([]byte, error)
+}
+
+// ExceptionError is returned when a slave responds with a Modbus exception
+// rather than the expected data.
+type ExceptionError struct {
+	FunctionCode  byte
+	ExceptionCode byte
+}
+
+func (e *ExceptionError) Error() string {
+	return fmt.Sprintf("modbus: slave returned exception code %#x for function %#x", e.ExceptionCode, e.FunctionCode&0x7F)
+}
+
+// checkException returns pdu unchanged, leading function code included, or
+// an *ExceptionError if the slave set the exception bit (0x80) on that code.
+func checkException(pdu []byte) ([]byte, error) {
+	if len(pdu) == 0 {
+		return nil, errors.New("modbus: empty response PDU")
+	}
+	if pdu[0]&0x80 != 0 {
+		if len(pdu) < 2 {
+			return nil, errors.New("modbus: truncated exception response")
+		}
+		return nil, &ExceptionError{FunctionCode: pdu[0], ExceptionCode: pdu[1]}
+	}
+	return pdu, nil
+}
+
+type client struct {
+	t transport
+}
+
+func newClient(t transport) Client {
+	return &client{t: t}
+}
+
+func (c *client) Close() error {
+	return c.t.Close()
+}
@@ -0,0 +1,196 @@
+// Copyright 2011 Aaron Jacobs. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/longchiwen/go-serial/serial"
+)
+
+// defaultTimeout bounds how long a Send waits for a complete response. It is
+// independent of OpenOptions.InterCharacterTimeout, which governs individual
+// Read calls on the underlying port rather than a whole request/response
+// round trip.
+const defaultTimeout = 1 * time.Second
+
+// rtuTransport implements RTU framing: [slaveID][PDU][CRC16 lo][CRC16 hi].
+type rtuTransport struct {
+	port       io.ReadWriteCloser
+	slaveID    byte
+	timeout    time.Duration
+	frameDelay time.Duration // inter-frame silent interval (T3.5)
+	charDelay  time.Duration // max inter-character gap (T1.5)
+	chunks     chan rtuChunk
+}
+
+type rtuChunk struct {
+	b   []byte
+	err error
+}
+
+// NewRTUClientHandler opens opts.PortName and returns a Client that speaks
+// Modbus RTU framing to the slave identified by slaveID.
+func NewRTUClientHandler(opts serial.OpenOptions, slaveID byte) (Client, error) {
+	port, err := serial.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &rtuTransport{
+		port:    port,
+		slaveID: slaveID,
+		timeout: defaultTimeout,
+		chunks:  make(chan rtuChunk, 16),
+	}
+	t.frameDelay, t.charDelay = rtuFrameGaps(opts.BaudRate)
+	go t.readLoop()
+
+	return newClient(t), nil
+}
+
+// readLoop owns the only Read call against port for the lifetime of the
+// transport. A single long-lived reader, rather than one goroutine per
+// Send, means a Send that times out never leaves a goroutine blocked
+// indefinitely on the next Read, and two Sends never race each other over
+// the same port.
+func (t *rtuTransport) readLoop() {
+	buf := make([]byte, 256)
+	for {
+		n, err := t.port.Read(buf)
+		if n > 0 {
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			t.chunks <- rtuChunk{b: b}
+		}
+		if err != nil {
+			t.chunks <- rtuChunk{err: err}
+			return
+		}
+	}
+}
+
+// drainStale discards any bytes still buffered from a previous Send that
+// timed out, so they aren't mistaken for part of the next response.
+func (t *rtuTransport) drainStale() {
+	for {
+		select {
+		case <-t.chunks:
+		default:
+			return
+		}
+	}
+}
+
+// rtuFrameGaps computes the RTU inter-frame silent interval (3.5 character
+// times) and the maximum inter-character gap (1.5 character times) for the
+// given baud rate. Per the Modbus spec, baud rates of 19200 or higher use
+// the fixed values of 1.75ms and 750us respectively, since the computed
+// times become unreliably small.
+func rtuFrameGaps(baudRate uint) (frameDelay, charDelay time.Duration) {
+	if baudRate == 0 {
+		baudRate = 19200
+	}
+	if baudRate >= 19200 {
+		return 1750 * time.Microsecond, 750 * time.Microsecond
+	}
+
+	// 11 bits per character: start bit, 8 data bits, parity/stop bits.
+	charTime := 11 * time.Second / time.Duration(baudRate)
+	return (35 * charTime) / 10, (15 * charTime) / 10
+}
+
+func (t *rtuTransport) Close() error {
+	return t.port.Close()
+}
+
+func (t *rtuTransport) Send(pdu []byte) ([]byte, error) {
+	frame := make([]byte, 0, len(pdu)+3)
+	frame = append(frame, t.slaveID)
+	frame = append(frame, pdu...)
+	crc := crc16(frame)
+	frame = append(frame, byte(crc), byte(crc>>8))
+
+	// Make sure the line has been silent for a full inter-frame interval
+	// before we claim it, so the slave doesn't mistake us for a continuation
+	// of whatever came before.
+	time.Sleep(t.frameDelay)
+	t.drainStale()
+
+	if _, err := t.port.Write(frame); err != nil {
+		return nil, fmt.Errorf("modbus: rtu write: %w", err)
+	}
+
+	resp, err := t.readFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(resp)
+	if n < 4 {
+		return nil, errors.New("modbus: rtu response too short")
+	}
+	gotCRC := uint16(resp[n-2]) | uint16(resp[n-1])<<8
+	if crc16(resp[:n-2]) != gotCRC {
+		return nil, errors.New("modbus: rtu response failed CRC check")
+	}
+	if resp[0] != t.slaveID {
+		return nil, fmt.Errorf("modbus: rtu response slave ID %d does not match request %d", resp[0], t.slaveID)
+	}
+
+	return checkException(resp[1 : n-2])
+}
+
+// readFrame reads a response frame, treating a gap of at least charDelay
+// with no new bytes as the end of the frame, per the RTU spec's rule that a
+// frame never contains a gap larger than 1.5 character times. It reads from
+// the shared channel readLoop populates rather than spawning its own
+// reader, since t.port is not safe for concurrent Reads.
+func (t *rtuTransport) readFrame() ([]byte, error) {
+	var resp []byte
+	overall := time.NewTimer(t.timeout)
+	defer overall.Stop()
+	idle := time.NewTimer(t.timeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case c := <-t.chunks:
+			if c.err != nil {
+				if len(resp) == 0 {
+					return nil, c.err
+				}
+				return resp, nil
+			}
+			resp = append(resp, c.b...)
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(t.charDelay)
+
+		case <-idle.C:
+			if len(resp) == 0 {
+				continue // the response hasn't started yet
+			}
+			return resp, nil
+
+		case <-overall.C:
+			return nil, errors.New("modbus: timed out waiting for rtu response")
+		}
+	}
+}
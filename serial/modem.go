@@ -0,0 +1,44 @@
+package serial
+
+import "time"
+
+// TriState represents an option that can be left alone, or pinned low or
+// high. It's used for OpenOptions.InitialDTR and OpenOptions.InitialRTS,
+// since toggling DTR the moment a port is opened (rather than leaving it to
+// whatever the platform defaults to) is what keeps boards that auto-reset
+// on DTR, like most Arduinos, from rebooting on every Open.
+type TriState int
+
+const (
+	// Unset leaves the line alone; Open neither asserts nor deasserts it.
+	Unset TriState = iota
+	Low
+	High
+)
+
+// ModemControl is implemented by the io.ReadWriteCloser returned by Open,
+// exposing the modem control lines and break signal that don't fit the
+// plain Read/Write/Close interface. Callers that need it type-assert:
+//
+//	port, err := serial.Open(options)
+//	...
+//	mc := port.(serial.ModemControl)
+//	mc.SetDTR(true)
+type ModemControl interface {
+	// SetDTR and SetRTS assert or deassert the Data Terminal Ready and
+	// Request To Send lines.
+	SetDTR(on bool) error
+	SetRTS(on bool) error
+
+	// GetCTS, GetDSR, GetRI, and GetCD report the current state of the
+	// Clear To Send, Data Set Ready, Ring Indicator, and Carrier Detect
+	// input lines, respectively.
+	GetCTS() (bool, error)
+	GetDSR() (bool, error)
+	GetRI() (bool, error)
+	GetCD() (bool, error)
+
+	// SendBreak asserts a break condition on the line for duration, then
+	// clears it.
+	SendBreak(duration time.Duration) error
+}
@@ -0,0 +1,90 @@
+//go:build !windows
+
+package serial
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// The modem control and break methods below are defined on *port (declared
+// per-platform alongside openInternal) so that type-asserting a port to
+// ModemControl reaches the real descriptor.
+
+func (p *port) setModemBits(bit int, set bool) error {
+	req := uintptr(syscall.TIOCMBIC)
+	if set {
+		req = uintptr(syscall.TIOCMBIS)
+	}
+	return ioctl(p.f.Fd(), req, uintptr(unsafe.Pointer(&bit)))
+}
+
+func (p *port) modemBits() (int, error) {
+	var bits int
+	if err := ioctl(p.f.Fd(), uintptr(syscall.TIOCMGET), uintptr(unsafe.Pointer(&bits))); err != nil {
+		return 0, fmt.Errorf("serial: TIOCMGET: %w", err)
+	}
+	return bits, nil
+}
+
+func (p *port) modemBitSet(bit int) (bool, error) {
+	bits, err := p.modemBits()
+	if err != nil {
+		return false, err
+	}
+	return bits&bit != 0, nil
+}
+
+func (p *port) SetDTR(on bool) error {
+	if err := p.setModemBits(syscall.TIOCM_DTR, on); err != nil {
+		return fmt.Errorf("serial: set DTR: %w", err)
+	}
+	return nil
+}
+
+func (p *port) SetRTS(on bool) error {
+	if err := p.setModemBits(syscall.TIOCM_RTS, on); err != nil {
+		return fmt.Errorf("serial: set RTS: %w", err)
+	}
+	return nil
+}
+
+func (p *port) GetCTS() (bool, error) { return p.modemBitSet(syscall.TIOCM_CTS) }
+func (p *port) GetDSR() (bool, error) { return p.modemBitSet(syscall.TIOCM_DSR) }
+func (p *port) GetRI() (bool, error)  { return p.modemBitSet(syscall.TIOCM_RI) }
+func (p *port) GetCD() (bool, error)  { return p.modemBitSet(syscall.TIOCM_CD) }
+
+// SendBreak asserts a break condition via TIOCSBRK, holds it for duration,
+// then clears it via TIOCCBRK.
+func (p *port) SendBreak(duration time.Duration) error {
+	if err := ioctl(p.f.Fd(), uintptr(syscall.TIOCSBRK), 0); err != nil {
+		return fmt.Errorf("serial: start break: %w", err)
+	}
+	time.Sleep(duration)
+	if err := ioctl(p.f.Fd(), uintptr(syscall.TIOCCBRK), 0); err != nil {
+		return fmt.Errorf("serial: clear break: %w", err)
+	}
+	return nil
+}
+
+// applyInitialModemLines sets DTR/RTS to the state requested by opts,
+// immediately after the port is opened and before the caller can race it
+// with a read or write. In particular, driving DTR low (or simply not
+// toggling it) on a board wired like an Arduino's auto-reset circuit avoids
+// the reboot-on-open that otherwise forces callers to sleep past the
+// bootloader.
+func (p *port) applyInitialModemLines(opts OpenOptions) error {
+	if opts.InitialDTR != Unset {
+		if err := p.SetDTR(opts.InitialDTR == High); err != nil {
+			return err
+		}
+	}
+	if opts.InitialRTS != Unset {
+		if err := p.SetRTS(opts.InitialRTS == High); err != nil {
+			return err
+		}
+	}
+	return nil
+}
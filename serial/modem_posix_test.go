@@ -0,0 +1,19 @@
+//go:build !windows
+
+package serial
+
+import (
+	"os"
+	"testing"
+)
+
+func TestApplyInitialModemLinesNoop(t *testing.T) {
+	// With both options left Unset, applyInitialModemLines must not touch
+	// the descriptor at all, so this should be safe even with a port whose
+	// file is not a real, open serial device.
+	p := &port{f: os.NewFile(^uintptr(0), "invalid")}
+
+	if err := p.applyInitialModemLines(OpenOptions{}); err != nil {
+		t.Errorf("applyInitialModemLines with no InitialDTR/InitialRTS returned %v, want nil", err)
+	}
+}
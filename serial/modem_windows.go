@@ -0,0 +1,108 @@
+//go:build windows
+
+package serial
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// The modem control and break methods below are defined on *port (declared
+// in serial_windows.go alongside openInternal) so that type-asserting a
+// port to ModemControl reaches the real handle.
+
+var (
+	procEscapeCommFunction = kernel32.NewProc("EscapeCommFunction")
+	procGetCommModemStatus = kernel32.NewProc("GetCommModemStatus")
+)
+
+// EscapeCommFunction function codes, from winbase.h.
+const (
+	setRTS   = 3
+	clrRTS   = 4
+	setDTR   = 5
+	clrDTR   = 6
+	setBreak = 8
+	clrBreak = 9
+)
+
+// GetCommModemStatus bits, from winbase.h.
+const (
+	msCTSOn  = 0x0010
+	msDSROn  = 0x0020
+	msRingOn = 0x0040
+	msRLSDOn = 0x0080 // carrier detect
+)
+
+func (p *port) escape(fn uintptr) error {
+	ok, _, err := procEscapeCommFunction.Call(uintptr(p.handle), fn)
+	if ok == 0 {
+		return fmt.Errorf("serial: EscapeCommFunction: %w", err)
+	}
+	return nil
+}
+
+func (p *port) SetDTR(on bool) error {
+	if on {
+		return p.escape(setDTR)
+	}
+	return p.escape(clrDTR)
+}
+
+func (p *port) SetRTS(on bool) error {
+	if on {
+		return p.escape(setRTS)
+	}
+	return p.escape(clrRTS)
+}
+
+func (p *port) modemStatus() (uint32, error) {
+	var status uint32
+	ok, _, err := procGetCommModemStatus.Call(uintptr(p.handle), uintptr(unsafe.Pointer(&status)))
+	if ok == 0 {
+		return 0, fmt.Errorf("serial: GetCommModemStatus: %w", err)
+	}
+	return status, nil
+}
+
+func (p *port) modemBitSet(bit uint32) (bool, error) {
+	status, err := p.modemStatus()
+	if err != nil {
+		return false, err
+	}
+	return status&bit != 0, nil
+}
+
+func (p *port) GetCTS() (bool, error) { return p.modemBitSet(msCTSOn) }
+func (p *port) GetDSR() (bool, error) { return p.modemBitSet(msDSROn) }
+func (p *port) GetRI() (bool, error)  { return p.modemBitSet(msRingOn) }
+func (p *port) GetCD() (bool, error)  { return p.modemBitSet(msRLSDOn) }
+
+// SendBreak asserts a break condition, holds it for duration, then clears
+// it.
+func (p *port) SendBreak(duration time.Duration) error {
+	if err := p.escape(setBreak); err != nil {
+		return err
+	}
+	time.Sleep(duration)
+	return p.escape(clrBreak)
+}
+
+// applyInitialModemLines sets DTR/RTS to the state requested by opts,
+// immediately after the port is opened. Driving DTR low on an Arduino-style
+// auto-reset circuit (or simply not touching it) avoids the reboot-on-open
+// that otherwise forces callers to sleep past the bootloader.
+func (p *port) applyInitialModemLines(opts OpenOptions) error {
+	if opts.InitialDTR != Unset {
+		if err := p.SetDTR(opts.InitialDTR == High); err != nil {
+			return err
+		}
+	}
+	if opts.InitialRTS != Unset {
+		if err := p.SetRTS(opts.InitialRTS == High); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package serial
+
+// PortInfo describes one serial port discovered on the system, with
+// whatever metadata the platform was able to provide. Fields that couldn't
+// be determined are left as the zero value.
+type PortInfo struct {
+	// Name is the path (POSIX) or name (Windows) to pass as
+	// OpenOptions.PortName, e.g. "/dev/ttyUSB0" or "COM3".
+	Name string
+
+	// Description is a platform-supplied human-readable label for the
+	// port, where available.
+	Description string
+
+	Manufacturer string
+	Product      string
+	SerialNumber string
+
+	// VID and PID are the USB vendor and product IDs, as four hex digits
+	// (e.g. "2341"), when IsUSB is true.
+	VID string
+	PID string
+
+	// IsUSB reports whether the port is backed by a USB device, which is
+	// what makes VID, PID, and SerialNumber meaningful.
+	IsUSB bool
+}
+
+// ListNames returns just the port names, for callers written against the
+// original List() []string API.
+func ListNames() []string {
+	ports := List()
+	names := make([]string, len(ports))
+	for i, p := range ports {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// FindPorts returns every currently-present port for which matcher returns
+// true, e.g. to locate an Arduino or FTDI adapter by VID/PID rather than a
+// hard-coded path.
+func FindPorts(matcher func(PortInfo) bool) []PortInfo {
+	var found []PortInfo
+	for _, p := range List() {
+		if matcher(p) {
+			found = append(found, p)
+		}
+	}
+	return found
+}
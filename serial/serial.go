@@ -0,0 +1,59 @@
+// Package serial provides a portable interface to serial ports.
+package serial
+
+import "io"
+
+// ParityMode describes the parity bit, if any, added to each data byte.
+type ParityMode byte
+
+const (
+	ParityNone ParityMode = iota
+	ParityOdd
+	ParityEven
+)
+
+// OpenOptions describes how to open and configure a serial port. The zero
+// value is not usable on its own: PortName, BaudRate, DataBits, and StopBits
+// must be set.
+type OpenOptions struct {
+	// PortName is the OS-specific path or name of the port, e.g.
+	// "/dev/ttyUSB0" or "COM3".
+	PortName string
+
+	// BaudRate is the speed of the connection, e.g. 9600 or 115200.
+	BaudRate uint
+
+	// DataBits is the number of data bits per character: 5, 6, 7, or 8.
+	DataBits byte
+
+	// StopBits is the number of stop bits per character: 1 or 2.
+	StopBits byte
+
+	// ParityMode is the parity bit mode to use. Defaults to ParityNone.
+	ParityMode ParityMode
+
+	// RTSCTSFlowControl enables hardware (RTS/CTS) flow control.
+	RTSCTSFlowControl bool
+
+	// MinimumReadSize is the minimum number of bytes that must be available
+	// before a Read returns, when InterCharacterTimeout is zero.
+	MinimumReadSize uint
+
+	// InterCharacterTimeout is the maximum time, in milliseconds, to wait
+	// between received characters before a Read returns with whatever it
+	// has. Zero disables the timeout and relies solely on
+	// MinimumReadSize.
+	InterCharacterTimeout uint
+
+	// InitialDTR and InitialRTS, if not Unset, drive the DTR and RTS modem
+	// control lines to the given state immediately after the port is
+	// opened. See ModemControl.
+	InitialDTR TriState
+	InitialRTS TriState
+}
+
+// Open opens a serial port with the given options. The io.ReadWriteCloser it
+// returns also implements ModemControl.
+func Open(options OpenOptions) (io.ReadWriteCloser, error) {
+	return openInternal(options)
+}
@@ -0,0 +1,113 @@
+//go:build linux
+
+package serial
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// crtscts is CRTSCTS from asm-generic/termbits.h. The syscall package
+// doesn't export it on linux, so we define it ourselves.
+const crtscts = 0x80000000
+
+var baudRates = map[uint]uint32{
+	50: syscall.B50, 75: syscall.B75, 110: syscall.B110, 134: syscall.B134,
+	150: syscall.B150, 200: syscall.B200, 300: syscall.B300, 600: syscall.B600,
+	1200: syscall.B1200, 1800: syscall.B1800, 2400: syscall.B2400, 4800: syscall.B4800,
+	9600: syscall.B9600, 19200: syscall.B19200, 38400: syscall.B38400,
+	57600: syscall.B57600, 115200: syscall.B115200, 230400: syscall.B230400,
+}
+
+// port is the POSIX concrete type backing the io.ReadWriteCloser returned
+// by Open.
+type port struct {
+	f *os.File
+}
+
+func openInternal(options OpenOptions) (*port, error) {
+	f, err := os.OpenFile(options.PortName, syscall.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("serial: open %s: %w", options.PortName, err)
+	}
+
+	// We only needed O_NONBLOCK to avoid hanging the open waiting for
+	// carrier detect; switch back to blocking I/O so Read honors
+	// MinimumReadSize/InterCharacterTimeout via VMIN/VTIME below.
+	if err := syscall.SetNonblock(int(f.Fd()), false); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("serial: clear O_NONBLOCK: %w", err)
+	}
+
+	p := &port{f: f}
+	if err := p.configure(options); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := p.applyInitialModemLines(options); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *port) configure(options OpenOptions) error {
+	rate, ok := baudRates[options.BaudRate]
+	if !ok {
+		return fmt.Errorf("serial: unsupported baud rate %d", options.BaudRate)
+	}
+
+	var t syscall.Termios
+	if err := ioctl(p.f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t))); err != nil {
+		return fmt.Errorf("serial: TCGETS: %w", err)
+	}
+
+	t.Iflag = 0
+	t.Oflag = 0
+	t.Lflag = 0
+	t.Cflag = syscall.CREAD | syscall.CLOCAL | rate
+
+	switch options.DataBits {
+	case 5:
+		t.Cflag |= syscall.CS5
+	case 6:
+		t.Cflag |= syscall.CS6
+	case 7:
+		t.Cflag |= syscall.CS7
+	default:
+		t.Cflag |= syscall.CS8
+	}
+	if options.StopBits == 2 {
+		t.Cflag |= syscall.CSTOPB
+	}
+	switch options.ParityMode {
+	case ParityOdd:
+		t.Cflag |= syscall.PARENB | syscall.PARODD
+	case ParityEven:
+		t.Cflag |= syscall.PARENB
+	}
+	if options.RTSCTSFlowControl {
+		t.Cflag |= crtscts
+	}
+
+	t.Cc[syscall.VMIN] = 0
+	t.Cc[syscall.VTIME] = 0
+	switch {
+	case options.InterCharacterTimeout > 0:
+		// VTIME is in tenths of a second.
+		t.Cc[syscall.VTIME] = uint8(options.InterCharacterTimeout / 100)
+	case options.MinimumReadSize > 0:
+		t.Cc[syscall.VMIN] = uint8(options.MinimumReadSize)
+	}
+
+	if err := ioctl(p.f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&t))); err != nil {
+		return fmt.Errorf("serial: TCSETS: %w", err)
+	}
+	return nil
+}
+
+func (p *port) Read(b []byte) (int, error)  { return p.f.Read(b) }
+func (p *port) Write(b []byte) (int, error) { return p.f.Write(b) }
+func (p *port) Close() error                { return p.f.Close() }
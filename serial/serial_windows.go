@@ -0,0 +1,155 @@
+//go:build windows
+
+package serial
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+var (
+	procGetCommState    = kernel32.NewProc("GetCommState")
+	procSetCommState    = kernel32.NewProc("SetCommState")
+	procSetCommTimeouts = kernel32.NewProc("SetCommTimeouts")
+)
+
+// dcb mirrors just the fields of the Win32 DCB struct that we need to set
+// baud rate, data bits, stop bits, parity, and RTS/CTS flow control.
+type dcb struct {
+	DCBlength  uint32
+	BaudRate   uint32
+	flags      uint32
+	wReserved  uint16
+	XonLim     uint16
+	XoffLim    uint16
+	ByteSize   byte
+	Parity     byte
+	StopBits   byte
+	XonChar    byte
+	XoffChar   byte
+	ErrorChar  byte
+	EofChar    byte
+	EvtChar    byte
+	wReserved1 uint16
+}
+
+// DCB flag bits, from winbase.h.
+const (
+	dcbBinary              = 1 << 0
+	dcbParity              = 1 << 1
+	dcbOutxCTSFlow         = 1 << 2
+	dcbRTSControlHandshake = 2 << 12 // fRtsControl == RTS_CONTROL_HANDSHAKE
+)
+
+// Win32 COMMTIMEOUTS.
+type commTimeouts struct {
+	ReadIntervalTimeout         uint32
+	ReadTotalTimeoutMultiplier  uint32
+	ReadTotalTimeoutConstant    uint32
+	WriteTotalTimeoutMultiplier uint32
+	WriteTotalTimeoutConstant   uint32
+}
+
+// port is the Windows concrete type backing the io.ReadWriteCloser returned
+// by Open.
+type port struct {
+	handle syscall.Handle
+}
+
+func openInternal(options OpenOptions) (*port, error) {
+	name, err := syscall.UTF16PtrFromString(`\\.\` + options.PortName)
+	if err != nil {
+		return nil, fmt.Errorf("serial: %w", err)
+	}
+
+	h, err := syscall.CreateFile(
+		name,
+		syscall.GENERIC_READ|syscall.GENERIC_WRITE,
+		0, nil,
+		syscall.OPEN_EXISTING,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0)
+	if err != nil {
+		return nil, fmt.Errorf("serial: open %s: %w", options.PortName, err)
+	}
+
+	p := &port{handle: h}
+	if err := p.configure(options); err != nil {
+		syscall.CloseHandle(h)
+		return nil, err
+	}
+	if err := p.applyInitialModemLines(options); err != nil {
+		syscall.CloseHandle(h)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *port) configure(options OpenOptions) error {
+	var d dcb
+	d.DCBlength = uint32(unsafe.Sizeof(d))
+	if ok, _, err := procGetCommState.Call(uintptr(p.handle), uintptr(unsafe.Pointer(&d))); ok == 0 {
+		return fmt.Errorf("serial: GetCommState: %w", err)
+	}
+
+	d.BaudRate = uint32(options.BaudRate)
+	d.ByteSize = options.DataBits
+	d.flags = dcbBinary
+
+	d.Parity = 0 // NOPARITY
+	if options.ParityMode != ParityNone {
+		d.flags |= dcbParity
+		if options.ParityMode == ParityOdd {
+			d.Parity = 1 // ODDPARITY
+		} else {
+			d.Parity = 2 // EVENPARITY
+		}
+	}
+
+	d.StopBits = 0 // ONESTOPBIT
+	if options.StopBits == 2 {
+		d.StopBits = 2 // TWOSTOPBITS
+	}
+
+	if options.RTSCTSFlowControl {
+		d.flags |= dcbOutxCTSFlow | dcbRTSControlHandshake
+	}
+
+	if ok, _, err := procSetCommState.Call(uintptr(p.handle), uintptr(unsafe.Pointer(&d))); ok == 0 {
+		return fmt.Errorf("serial: SetCommState: %w", err)
+	}
+
+	var t commTimeouts
+	if options.InterCharacterTimeout > 0 {
+		t.ReadIntervalTimeout = uint32(options.InterCharacterTimeout)
+		t.ReadTotalTimeoutConstant = uint32(options.InterCharacterTimeout)
+	}
+	if ok, _, err := procSetCommTimeouts.Call(uintptr(p.handle), uintptr(unsafe.Pointer(&t))); ok == 0 {
+		return fmt.Errorf("serial: SetCommTimeouts: %w", err)
+	}
+
+	return nil
+}
+
+func (p *port) Read(b []byte) (int, error) {
+	var n uint32
+	if err := syscall.ReadFile(p.handle, b, &n, nil); err != nil {
+		return int(n), fmt.Errorf("serial: read: %w", err)
+	}
+	return int(n), nil
+}
+
+func (p *port) Write(b []byte) (int, error) {
+	var n uint32
+	if err := syscall.WriteFile(p.handle, b, &n, nil); err != nil {
+		return int(n), fmt.Errorf("serial: write: %w", err)
+	}
+	return int(n), nil
+}
+
+func (p *port) Close() error {
+	return syscall.CloseHandle(p.handle)
+}
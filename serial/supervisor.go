@@ -0,0 +1,270 @@
+package serial
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// PortMatcher identifies candidate ports by metadata such as VID/PID or
+// serial number, for following a device across renumbering (e.g.
+// /dev/ttyUSB0 reappearing as /dev/ttyUSB1) rather than waiting for one
+// fixed path. It has the same signature as the matcher passed to
+// FindPorts, and is checked against List's output.
+type PortMatcher func(PortInfo) bool
+
+// ReconnectPolicy controls how a Supervisor behaves when its underlying
+// port disappears.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first reconnect attempt after
+	// a failure. Defaults to 250ms if zero.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff between reconnect attempts.
+	// Defaults to 10s if zero.
+	MaxBackoff time.Duration
+
+	// Multiplier scales the backoff after each failed attempt. Defaults to
+	// 2 if zero.
+	Multiplier float64
+
+	// Matcher, if non-nil, is consulted while reconnecting instead of
+	// waiting for opts.PortName specifically, so the Supervisor can follow
+	// a device that comes back under a different name.
+	Matcher PortMatcher
+}
+
+func (p ReconnectPolicy) withDefaults() ReconnectPolicy {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 250 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 10 * time.Second
+	}
+	if p.Multiplier <= 0 {
+		p.Multiplier = 2
+	}
+	return p
+}
+
+// Supervisor wraps a port opened with Open, transparently reopening it when
+// the device disappears (a USB unplug/replug, or Read/Write failing with a
+// disconnect-class error) and reappears. It implements io.ReadWriteCloser.
+type Supervisor struct {
+	opts   OpenOptions
+	policy ReconnectPolicy
+
+	// Reconnected receives a value each time the underlying port has been
+	// successfully (re)opened, including the first time. It holds at most
+	// one pending value; a (re)open while that value is still unreceived
+	// sends nothing more.
+	Reconnected chan struct{}
+
+	mu       sync.Mutex
+	port     io.ReadWriteCloser
+	ioErr    chan struct{} // signaled by Read/Write on a disconnect-class error
+	closed   bool
+	closedCh chan struct{}
+}
+
+// NewSupervisor returns a Supervisor for opts, immediately starting a
+// background goroutine that opens the port and keeps it open according to
+// policy. Read and Write block until the first connection succeeds.
+func NewSupervisor(opts OpenOptions, policy ReconnectPolicy) *Supervisor {
+	s := &Supervisor{
+		opts:        opts,
+		policy:      policy.withDefaults(),
+		Reconnected: make(chan struct{}, 1),
+		closedCh:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// run owns the connect/reconnect loop for the lifetime of the Supervisor.
+func (s *Supervisor) run() {
+	for {
+		port := s.connect()
+		if port == nil {
+			return // closed while waiting to (re)connect
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			port.Close()
+			return
+		}
+		s.port = port
+		s.ioErr = make(chan struct{}, 1)
+		ioErr := s.ioErr
+		s.mu.Unlock()
+
+		select {
+		case s.Reconnected <- struct{}{}:
+		default:
+		}
+
+		s.waitForDisconnect(port, ioErr)
+
+		s.mu.Lock()
+		if s.port == port {
+			s.port = nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+		port.Close()
+		if closed {
+			return
+		}
+	}
+}
+
+// openPort is a seam over Open so tests can exercise the reconnect loop
+// against a fake port instead of a real device.
+var openPort = Open
+
+// connect retries openPort with exponential backoff, using a platform
+// watcher to wait for the device to actually be present between attempts
+// rather than busy-looping. It returns nil if the Supervisor is closed
+// first.
+func (s *Supervisor) connect() io.ReadWriteCloser {
+	backoff := s.policy.InitialBackoff
+	for {
+		if s.isClosed() {
+			return nil
+		}
+
+		if s.policy.Matcher != nil {
+			for _, p := range listPorts() {
+				if s.policy.Matcher(p) {
+					s.opts.PortName = p.Name
+					break
+				}
+			}
+		}
+
+		port, err := openPort(s.opts)
+		if err == nil {
+			return port
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), backoff)
+		waitForDeviceReady(ctx, s.opts.PortName, s.policy.Matcher)
+		cancel()
+
+		backoff = time.Duration(float64(backoff) * s.policy.Multiplier)
+		if backoff > s.policy.MaxBackoff {
+			backoff = s.policy.MaxBackoff
+		}
+	}
+}
+
+// waitForDisconnect blocks until the port is reported gone, either because
+// Read/Write observed a disconnect-class error on ioErr, or because the
+// platform watcher notices the device file itself disappear (the case where
+// nobody happens to be reading or writing at the moment of the unplug).
+func (s *Supervisor) waitForDisconnect(port io.ReadWriteCloser, ioErr chan struct{}) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ioErr:
+			return
+		case <-s.closedCh:
+			return
+		case <-ticker.C:
+			if s.opts.PortName != "" && !devicePresent(s.opts.PortName) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Supervisor) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// noteIOErr records that an I/O error looked like a disconnect, waking the
+// reconnect loop without waiting for the next watcher poll.
+func (s *Supervisor) noteIOErr() {
+	s.mu.Lock()
+	ioErr := s.ioErr
+	s.mu.Unlock()
+	if ioErr == nil {
+		return
+	}
+	select {
+	case ioErr <- struct{}{}:
+	default:
+	}
+}
+
+// currentPort returns the live port, blocking while a reconnect is in
+// progress. It returns an error once the Supervisor has been closed.
+func (s *Supervisor) currentPort() (io.ReadWriteCloser, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, errors.New("serial: supervisor is closed")
+		}
+		port := s.port
+		s.mu.Unlock()
+		if port != nil {
+			return port, nil
+		}
+
+		select {
+		case <-s.closedCh:
+			return nil, errors.New("serial: supervisor is closed")
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (s *Supervisor) Read(p []byte) (int, error) {
+	port, err := s.currentPort()
+	if err != nil {
+		return 0, err
+	}
+	n, err := port.Read(p)
+	if err != nil && isDisconnectErr(err) {
+		s.noteIOErr()
+	}
+	return n, err
+}
+
+func (s *Supervisor) Write(p []byte) (int, error) {
+	port, err := s.currentPort()
+	if err != nil {
+		return 0, err
+	}
+	n, err := port.Write(p)
+	if err != nil && isDisconnectErr(err) {
+		s.noteIOErr()
+	}
+	return n, err
+}
+
+// Close stops the reconnect loop and closes the current port, if any.
+func (s *Supervisor) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	port := s.port
+	s.mu.Unlock()
+	close(s.closedCh)
+
+	if port != nil {
+		return port.Close()
+	}
+	return nil
+}
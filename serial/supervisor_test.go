@@ -0,0 +1,144 @@
+package serial
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReconnectPolicyDefaults(t *testing.T) {
+	p := ReconnectPolicy{}.withDefaults()
+
+	if p.InitialBackoff != 250*time.Millisecond {
+		t.Errorf("InitialBackoff = %v, want 250ms", p.InitialBackoff)
+	}
+	if p.MaxBackoff != 10*time.Second {
+		t.Errorf("MaxBackoff = %v, want 10s", p.MaxBackoff)
+	}
+	if p.Multiplier != 2 {
+		t.Errorf("Multiplier = %v, want 2", p.Multiplier)
+	}
+}
+
+func TestReconnectPolicyRespectsExplicitValues(t *testing.T) {
+	p := ReconnectPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     1.5,
+	}.withDefaults()
+
+	if p.InitialBackoff != time.Second {
+		t.Errorf("InitialBackoff = %v, want 1s", p.InitialBackoff)
+	}
+	if p.MaxBackoff != time.Minute {
+		t.Errorf("MaxBackoff = %v, want 1m", p.MaxBackoff)
+	}
+	if p.Multiplier != 1.5 {
+		t.Errorf("Multiplier = %v, want 1.5", p.Multiplier)
+	}
+}
+
+// fakePort is an io.ReadWriteCloser standing in for a real device: Read
+// returns each of reads in turn (nil meaning io.EOF, as if the device had
+// been unplugged) and then blocks, like an idle open port with nothing
+// more to deliver.
+type fakePort struct {
+	mu     sync.Mutex
+	reads  [][]byte
+	idx    int
+	closed bool
+}
+
+func (p *fakePort) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	if p.idx < len(p.reads) {
+		data := p.reads[p.idx]
+		p.idx++
+		p.mu.Unlock()
+		if data == nil {
+			return 0, io.EOF
+		}
+		return copy(b, data), nil
+	}
+	p.mu.Unlock()
+	select {} // no more data queued; behave like a port nobody is writing to
+}
+
+func (p *fakePort) Write(b []byte) (int, error) { return len(b), nil }
+
+func (p *fakePort) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fakePort) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// TestSupervisorReconnectsAfterDisconnect drives a full disconnect/reconnect
+// cycle through a Supervisor without a real device: openPort is stubbed to
+// hand out two fakePorts in turn, and the first one reports a disconnect by
+// returning io.EOF from Read.
+func TestSupervisorReconnectsAfterDisconnect(t *testing.T) {
+	port1 := &fakePort{reads: [][]byte{nil}} // its first Read returns io.EOF
+	port2 := &fakePort{reads: [][]byte{[]byte("hello")}}
+
+	calls := 0
+	origOpenPort := openPort
+	openPort = func(OpenOptions) (io.ReadWriteCloser, error) {
+		calls++
+		if calls == 1 {
+			return port1, nil
+		}
+		return port2, nil
+	}
+	defer func() { openPort = origOpenPort }()
+
+	s := NewSupervisor(OpenOptions{}, ReconnectPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	defer s.Close()
+
+	waitReconnected := func() {
+		select {
+		case <-s.Reconnected:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for Reconnected")
+		}
+	}
+
+	waitReconnected() // port1 connected
+
+	buf := make([]byte, 16)
+	if _, err := s.Read(buf); !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() during disconnect = %v, want io.EOF", err)
+	}
+
+	waitReconnected() // port2 connected after the reconnect loop noticed the EOF
+
+	n, err := s.Read(buf)
+	if err != nil {
+		t.Fatalf("Read() after reconnect: %v", err)
+	}
+	if got := string(buf[:n]); got != "hello" {
+		t.Errorf("Read() after reconnect = %q, want %q (should be reading port2, not port1)", got, "hello")
+	}
+
+	if !port1.isClosed() {
+		t.Error("port1 was never closed after the Supervisor reconnected away from it")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if !port2.isClosed() {
+		t.Error("port2 was never closed by Supervisor.Close()")
+	}
+}
@@ -0,0 +1,61 @@
+package serial
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// pollInterval is how often the portable fallback watcher re-checks for the
+// device file. It is also used as the Supervisor's polling period for
+// noticing a disconnect that happens while nobody is reading or writing.
+const pollInterval = 500 * time.Millisecond
+
+// pollForDevice blocks until name exists, ctx is done, or (if matcher is
+// non-nil) some port accepted by matcher shows up in List(). It is the
+// fallback watcher used on every platform, and the only watcher on anything
+// but Linux.
+func pollForDevice(ctx context.Context, name string, matcher PortMatcher) {
+	if deviceReady(name, matcher) {
+		return
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if deviceReady(name, matcher) {
+				return
+			}
+		}
+	}
+}
+
+// listPorts is a seam over List so tests can exercise matcher-based
+// reconnect against fake PortInfo values instead of real hardware.
+var listPorts = List
+
+func deviceReady(name string, matcher PortMatcher) bool {
+	if matcher != nil {
+		for _, p := range listPorts() {
+			if matcher(p) {
+				return true
+			}
+		}
+		return false
+	}
+	return devicePresent(name)
+}
+
+// devicePresent reports whether name currently exists, used by the
+// Supervisor's disconnect watcher to notice an unplug even when nobody is
+// actively reading or writing.
+func devicePresent(name string) bool {
+	if name == "" {
+		return true
+	}
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,86 @@
+//go:build linux
+
+package serial
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// waitForDeviceReady waits for name to (re)appear. With no matcher, it uses
+// inotify on the device's parent directory (udev creates/removes tty nodes
+// there on hotplug) so the reconnect doesn't have to poll; pollForDevice is
+// the backstop if the watch can't be set up, or if matcher requires
+// scanning List() instead of watching one fixed path.
+func waitForDeviceReady(ctx context.Context, name string, matcher PortMatcher) {
+	if matcher != nil || name == "" {
+		pollForDevice(ctx, name, matcher)
+		return
+	}
+	if devicePresent(name) {
+		return
+	}
+
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		pollForDevice(ctx, name, matcher)
+		return
+	}
+	defer syscall.Close(fd)
+
+	dir := filepath.Dir(name)
+	base := filepath.Base(name)
+	if _, err := syscall.InotifyAddWatch(fd, dir, syscall.IN_CREATE|syscall.IN_MOVED_TO|syscall.IN_ATTRIB); err != nil {
+		pollForDevice(ctx, name, matcher)
+		return
+	}
+
+	appeared := make(chan struct{})
+	go watchInotifyFor(fd, base, appeared)
+
+	select {
+	case <-ctx.Done():
+	case <-appeared:
+	}
+}
+
+// watchInotifyFor blocks in Read until an event names base, or fd is closed
+// out from under it by the caller (waitForDeviceReady's deferred
+// syscall.Close, on ctx cancellation), which unblocks the read with an
+// error. fd is opened without IN_NONBLOCK for exactly this reason: a
+// non-blocking read returns EAGAIN the instant the event queue is empty,
+// which an error-only exit treats identically to "fd closed", closing
+// appeared immediately and turning every reconnect into a busy spin.
+func watchInotifyFor(fd int, base string, appeared chan<- struct{}) {
+	defer close(appeared)
+
+	buf := make([]byte, syscall.SizeofInotifyEvent+syscall.NAME_MAX+1)
+	for {
+		n, err := syscall.Read(fd, buf)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || n <= 0 {
+			return
+		}
+
+		offset := 0
+		for offset+syscall.SizeofInotifyEvent <= n {
+			raw := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameLen := int(raw.Len)
+			if nameLen > 0 {
+				eventName := strings.TrimRight(
+					string(buf[offset+syscall.SizeofInotifyEvent:offset+syscall.SizeofInotifyEvent+nameLen]),
+					"\x00",
+				)
+				if eventName == base {
+					return
+				}
+			}
+			offset += syscall.SizeofInotifyEvent + nameLen
+		}
+	}
+}
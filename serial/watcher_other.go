@@ -0,0 +1,12 @@
+//go:build !linux
+
+package serial
+
+import "context"
+
+// waitForDeviceReady waits for name to (re)appear, or for a port accepted
+// by matcher to show up in List(). macOS and Windows don't yet have a
+// native hotplug watcher wired in here, so both fall back to polling.
+func waitForDeviceReady(ctx context.Context, name string, matcher PortMatcher) {
+	pollForDevice(ctx, name, matcher)
+}
@@ -0,0 +1,78 @@
+package serial
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestDeviceReadyMatchesByVIDPID proves a PortMatcher can follow a device
+// by VID/PID rather than a fixed path, e.g. "the first FTDI FT232R on this
+// bus" (VID 0403, PID 6001), using listPorts as a seam over List so no real
+// hardware is needed.
+func TestDeviceReadyMatchesByVIDPID(t *testing.T) {
+	origListPorts := listPorts
+	defer func() { listPorts = origListPorts }()
+
+	ftdi := PortMatcher(func(p PortInfo) bool {
+		return p.IsUSB && p.VID == "0403" && p.PID == "6001"
+	})
+
+	listPorts = func() []PortInfo {
+		return []PortInfo{
+			{Name: "/dev/ttyUSB0", IsUSB: true, VID: "10c4", PID: "ea60"},
+		}
+	}
+	if deviceReady("", ftdi) {
+		t.Fatal("deviceReady() = true before the matching VID/PID appeared")
+	}
+
+	listPorts = func() []PortInfo {
+		return []PortInfo{
+			{Name: "/dev/ttyUSB0", IsUSB: true, VID: "10c4", PID: "ea60"},
+			{Name: "/dev/ttyUSB1", IsUSB: true, VID: "0403", PID: "6001"},
+		}
+	}
+	if !deviceReady("", ftdi) {
+		t.Fatal("deviceReady() = false once the matching VID/PID appeared")
+	}
+}
+
+// TestPollForDeviceUsesMatcher exercises the same matcher through
+// pollForDevice, which is what Supervisor actually calls while reconnecting.
+func TestPollForDeviceUsesMatcher(t *testing.T) {
+	origListPorts := listPorts
+	defer func() { listPorts = origListPorts }()
+
+	matched := make(chan struct{})
+	listPorts = func() []PortInfo {
+		select {
+		case <-matched:
+			return []PortInfo{{Name: "/dev/ttyACM0", IsUSB: true, VID: "2341", PID: "0043"}}
+		default:
+			return nil
+		}
+	}
+
+	arduinoUno := PortMatcher(func(p PortInfo) bool {
+		return p.IsUSB && p.VID == "2341" && p.PID == "0043"
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		pollForDevice(ctx, "", arduinoUno)
+		close(done)
+	}()
+
+	time.Sleep(2 * pollInterval)
+	close(matched)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pollForDevice never returned after the matcher started matching")
+	}
+}